@@ -34,13 +34,29 @@ var buildEnvFlags = struct {
 	machineType string
 	timeout     string
 	concurrency int
+	workerPool  string
+	region      string
+
+	awsRegion      string
+	awsProjectName string
+	awsComputeType string
+
+	azureRegion            string
+	azureServiceConnection string
+	azureProjectName       string
+
+	dryRun       bool
+	outputFormat string
+
+	set      []string
+	fromFile string
 }{}
 
 func cmdBuildEnv() *cobra.Command {
 	return NewCmd("build-env").
 		WithDescription("Interact with skaffold build environment definitions.").
 		WithPersistentFlagAdder(cmdBuildEnvFlags).
-		WithCommands(cmdBuildEnvList(), cmdBuildEnvAdd())
+		WithCommands(cmdBuildEnvList(), cmdBuildEnvAdd(), cmdBuildEnvModify(), cmdBuildEnvRemove(), cmdBuildEnvValidate())
 }
 
 func cmdBuildEnvList() *cobra.Command {
@@ -51,11 +67,21 @@ func cmdBuildEnvList() *cobra.Command {
 		NoArgs(listBuildEnv)
 }
 
+func cmdBuildEnvValidate() *cobra.Command {
+	return NewCmd("validate").
+		WithExample("Validate the active build environments with activated profiles p1 and p2", "inspect build-env validate -p p1,p2 --format json").
+		WithDescription("Statically validate active build environment definitions against provider constraints, without dispatching a build.").
+		WithLongDescription(`Loads the resolved pipelines, honoring '--profile' and '--module', and for each active GoogleCloudBuild build environment checks Cloud Build constraints: that 'machineType' is a recognized value, 'diskSizeGb' falls within [10, 4000], 'timeout' parses as a duration, 'concurrency' is non-negative, 'projectId' is set, and 'workerPool' (if set) is a well-formed resource name. AWSCodeBuild and AzurePipelines definitions are not checked and are always reported valid.
+Exits non-zero if any active build environment definition fails validation, so it can be used as a CI gate.`).
+		WithFlagAdder(cmdBuildEnvListFlags).
+		NoArgs(validateBuildEnv)
+}
+
 func cmdBuildEnvAdd() *cobra.Command {
 	return NewCmd("add").
 		WithDescription("Add a new build environment to the default pipeline or to a new or existing profile.").
 		WithPersistentFlagAdder(cmdBuildEnvAddFlags).
-		WithCommands(cmdBuildEnvAddGcb())
+		WithCommands(cmdBuildEnvAddGcb(), cmdBuildEnvAddAws(), cmdBuildEnvAddAzure())
 }
 
 func cmdBuildEnvAddGcb() *cobra.Command {
@@ -66,20 +92,109 @@ Without the '--profile' flag the new environment definition is added to the defa
 In these respective scenarios, it will fail if the build env definition for the default pipeline or the named profile already exists. To override an existing definition use 'skaffold inspect build-env modify' command instead. 
 Use the '--module' filter to specify the individual module to target. Otherwise, it'll be applied to all modules defined in the target file. Also, with the '--profile' flag if the target config imports other configs as dependencies, then the new profile will be recursively created in all the imported configs also.`).
 		WithExample("Add a new profile named 'gcb' targeting the builder 'googleCloudBuild' against the GCP project ID '1234'.", "inspect build-env add googleCloudBuild --profile gcb --projectID 1234 -f skaffold.yaml").
+		WithExample("Apply the same machineType override to every module matching '--module' across a multi-module project.", "inspect build-env add googleCloudBuild --module svc-a,svc-b --set machineType=N1_HIGHCPU_8 -f skaffold.yaml").
 		WithFlagAdder(cmdBuildEnvAddGcbFlags).
 		NoArgs(addGcbBuildEnv)
 }
 
+func cmdBuildEnvAddAws() *cobra.Command {
+	return NewCmd("awsCodeBuild").
+		WithDescription("Add a new AWS CodeBuild build environment definition").
+		WithLongDescription(`Add a new AWS CodeBuild build environment definition.
+Without the '--profile' flag the new environment definition is added to the default pipeline. With the '--profile' flag it will create a new profile with this build env definition.
+In these respective scenarios, it will fail if the build env definition for the default pipeline or the named profile already exists. To override an existing definition use 'skaffold inspect build-env modify' command instead.
+Use the '--module' filter to specify the individual module to target. Otherwise, it'll be applied to all modules defined in the target file. Also, with the '--profile' flag if the target config imports other configs as dependencies, then the new profile will be recursively created in all the imported configs also.`).
+		WithExample("Add a new profile named 'aws' targeting the builder 'awsCodeBuild' against the region 'us-east-1'.", "inspect build-env add awsCodeBuild --profile aws --region us-east-1 --projectName my-project -f skaffold.yaml").
+		WithFlagAdder(cmdBuildEnvAddAwsFlags).
+		NoArgs(addAwsBuildEnv)
+}
+
+func cmdBuildEnvAddAzure() *cobra.Command {
+	return NewCmd("azurePipelines").
+		WithDescription("Add a new Azure Pipelines build environment definition").
+		WithLongDescription(`Add a new Azure Pipelines build environment definition.
+Without the '--profile' flag the new environment definition is added to the default pipeline. With the '--profile' flag it will create a new profile with this build env definition.
+In these respective scenarios, it will fail if the build env definition for the default pipeline or the named profile already exists. To override an existing definition use 'skaffold inspect build-env modify' command instead.
+Use the '--module' filter to specify the individual module to target. Otherwise, it'll be applied to all modules defined in the target file. Also, with the '--profile' flag if the target config imports other configs as dependencies, then the new profile will be recursively created in all the imported configs also.`).
+		WithExample("Add a new profile named 'azure' targeting the builder 'azurePipelines' against the service connection 'my-connection'.", "inspect build-env add azurePipelines --profile azure --serviceConnection my-connection --projectName my-project -f skaffold.yaml").
+		WithFlagAdder(cmdBuildEnvAddAzureFlags).
+		NoArgs(addAzureBuildEnv)
+}
+
+func cmdBuildEnvRemove() *cobra.Command {
+	return NewCmd("remove").
+		WithDescription("Remove an existing build environment definition from the default pipeline or an existing profile.").
+		WithPersistentFlagAdder(cmdBuildEnvAddFlags).
+		WithCommands(cmdBuildEnvRemoveGcb())
+}
+
+func cmdBuildEnvRemoveGcb() *cobra.Command {
+	return NewCmd("googleCloudBuild").
+		WithDescription("Remove an existing GoogleCloudBuild build environment definition").
+		WithLongDescription(`Remove an existing GoogleCloudBuild build environment definition.
+Without the '--profile' flag the definition is removed from the default pipeline. With the '--profile' flag the definition is removed from the named profile instead.
+It will fail if the build env definition for the default pipeline or the named profile does not already exist.
+Use the '--module' filter to specify the individual module to target. Otherwise, it'll be applied to all modules defined in the target file. Also, with the '--profile' flag if the target config imports other configs as dependencies, then the profile will be recursively updated in all the imported configs also.
+Use '--dry-run' to print the diff of the change that would be made without writing it to disk.`).
+		WithExample("Remove the 'gcb' profile's build env definition.", "inspect build-env remove googleCloudBuild --profile gcb -f skaffold.yaml").
+		WithExample("Preview the removal of the 'gcb' profile's build env definition as JSON.", "inspect build-env remove googleCloudBuild --profile gcb --dry-run --output json -f skaffold.yaml").
+		NoArgs(removeGcbBuildEnv)
+}
+
+func cmdBuildEnvModify() *cobra.Command {
+	return NewCmd("modify").
+		WithDescription("Update an existing build environment definition in the default pipeline or an existing profile.").
+		WithPersistentFlagAdder(cmdBuildEnvAddFlags).
+		WithCommands(cmdBuildEnvModifyGcb())
+}
+
+func cmdBuildEnvModifyGcb() *cobra.Command {
+	return NewCmd("googleCloudBuild").
+		WithDescription("Update an existing GoogleCloudBuild build environment definition").
+		WithLongDescription(`Update an existing GoogleCloudBuild build environment definition.
+Without the '--profile' flag the definition in the default pipeline is updated. With the '--profile' flag the definition in the named profile is updated instead.
+In these respective scenarios, it will fail if the build env definition for the default pipeline or the named profile does not already exist. To add a new definition use 'skaffold inspect build-env add' command instead.
+Only the flags explicitly set are patched; all other fields of the existing definition are preserved.
+Use the '--module' filter to specify the individual module to target. Otherwise, it'll be applied to all modules defined in the target file. Also, with the '--profile' flag if the target config imports other configs as dependencies, then the profile will be recursively updated in all the imported configs also.`).
+		WithExample("Update the GCP project ID of the 'gcb' profile to '1234'.", "inspect build-env modify googleCloudBuild --profile gcb --projectId 1234 -f skaffold.yaml").
+		WithFlagAdder(cmdBuildEnvAddGcbFlags).
+		NoArgs(modifyGcbBuildEnv)
+}
+
 func listBuildEnv(ctx context.Context, out io.Writer) error {
 	return buildEnv.PrintBuildEnvsList(ctx, out, printBuildEnvsListOptions())
 }
 
+func validateBuildEnv(ctx context.Context, out io.Writer) error {
+	return buildEnv.ValidateBuildEnvs(ctx, out, printBuildEnvsListOptions())
+}
+
 func addGcbBuildEnv(ctx context.Context, out io.Writer) error {
 	return buildEnv.AddGcbBuildEnv(ctx, out, addGcbBuildEnvOptions())
 }
 
+func addAwsBuildEnv(ctx context.Context, out io.Writer) error {
+	return buildEnv.AddAwsBuildEnv(ctx, out, addAwsBuildEnvOptions())
+}
+
+func addAzureBuildEnv(ctx context.Context, out io.Writer) error {
+	return buildEnv.AddAzureBuildEnv(ctx, out, addAzureBuildEnvOptions())
+}
+
+func modifyGcbBuildEnv(ctx context.Context, out io.Writer) error {
+	return buildEnv.ModifyGcbBuildEnv(ctx, out, addGcbBuildEnvOptions())
+}
+
+func removeGcbBuildEnv(ctx context.Context, out io.Writer) error {
+	return buildEnv.RemoveGcbBuildEnv(ctx, out, addGcbBuildEnvOptions())
+}
+
 func cmdBuildEnvAddFlags(f *pflag.FlagSet) {
 	f.StringVarP(&buildEnvFlags.profile, "profile", "p", "", `Profile name to add the new build env definition in. If the profile name doesn't exist then the profile will be created in all the target configs. If this flag is not specified then the build env is added to the default pipeline of the target configs.`)
+	f.BoolVar(&buildEnvFlags.dryRun, "dry-run", false, `Print a structured diff of the pipeline/profile changes instead of writing them to the target file.`)
+	f.StringVar(&buildEnvFlags.outputFormat, "output", "json", `Format to print the '--dry-run' diff in. One of 'json' or 'yaml'.`)
+	f.StringArrayVar(&buildEnvFlags.set, "set", nil, `Key=value override, e.g. '--set machineType=N1_HIGHCPU_8'. Repeatable. An explicit provider flag for the same key takes precedence. Applied to every module/profile selected by '--module'/'--profile'. Only 'add googleCloudBuild' reads it; the other add, modify and remove subcommands ignore it.`)
+	f.StringVar(&buildEnvFlags.fromFile, "from-file", "", `Path to a YAML overlay file of key/value overrides, applied the same way as repeated '--set' flags.`)
 }
 
 func cmdBuildEnvAddGcbFlags(f *pflag.FlagSet) {
@@ -88,6 +203,24 @@ func cmdBuildEnvAddGcbFlags(f *pflag.FlagSet) {
 	f.StringVar(&buildEnvFlags.machineType, "machineType", "", `Type of VM that runs the build`)
 	f.StringVar(&buildEnvFlags.timeout, "timeout", "", `Build timeout (in seconds)`)
 	f.IntVar(&buildEnvFlags.concurrency, "concurrency", -1, `number of artifacts to build concurrently. 0 means "no-limit"`)
+	f.StringVar(&buildEnvFlags.workerPool, "workerPool", "", `Fully qualified resource name of the private worker pool to run the build in, e.g. "projects/[project]/locations/[location]/workerPools/[workerPool]". Mutually exclusive with 'machineType' and 'diskSizeGb', which are pool-level settings.`)
+	f.StringVar(&buildEnvFlags.region, "region", "", `Cloud Build regional endpoint to use, e.g. "us-central1". Must match the location encoded in 'workerPool' when set.`)
+}
+
+func cmdBuildEnvAddAwsFlags(f *pflag.FlagSet) {
+	f.StringVar(&buildEnvFlags.awsRegion, "region", "", `AWS region to run the build in.`)
+	f.StringVar(&buildEnvFlags.awsProjectName, "projectName", "", `Name of the AWS CodeBuild project.`)
+	f.StringVar(&buildEnvFlags.awsComputeType, "computeType", "", `Compute type to use for the build (e.g. BUILD_GENERAL1_SMALL).`)
+	f.StringVar(&buildEnvFlags.timeout, "timeout", "", `Build timeout (in seconds)`)
+	f.IntVar(&buildEnvFlags.concurrency, "concurrency", -1, `number of artifacts to build concurrently. 0 means "no-limit"`)
+}
+
+func cmdBuildEnvAddAzureFlags(f *pflag.FlagSet) {
+	f.StringVar(&buildEnvFlags.azureServiceConnection, "serviceConnection", "", `Name of the Azure service connection to use for the build.`)
+	f.StringVar(&buildEnvFlags.azureProjectName, "projectName", "", `Name of the Azure DevOps project.`)
+	f.StringVar(&buildEnvFlags.azureRegion, "region", "", `Azure region to run the build in.`)
+	f.StringVar(&buildEnvFlags.timeout, "timeout", "", `Build timeout (in seconds)`)
+	f.IntVar(&buildEnvFlags.concurrency, "concurrency", -1, `number of artifacts to build concurrently. 0 means "no-limit"`)
 }
 
 func cmdBuildEnvFlags(f *pflag.FlagSet) {
@@ -120,6 +253,48 @@ func addGcbBuildEnvOptions() inspect.Options {
 			MachineType: buildEnvFlags.machineType,
 			Timeout:     buildEnvFlags.timeout,
 			Concurrency: buildEnvFlags.concurrency,
+			WorkerPool:  buildEnvFlags.workerPool,
+			Region:      buildEnvFlags.region,
+			DryRun:      buildEnvFlags.dryRun,
+			Output:      buildEnvFlags.outputFormat,
+			Set:         buildEnvFlags.set,
+			FromFile:    buildEnvFlags.fromFile,
+		},
+	}
+}
+
+func addAwsBuildEnvOptions() inspect.Options {
+	return inspect.Options{
+		Filename:  inspectFlags.fileName,
+		OutFormat: inspectFlags.outFormat,
+		Modules:   inspectFlags.modules,
+		BuildEnvOptions: inspect.BuildEnvOptions{
+			Profile:     buildEnvFlags.profile,
+			Region:      buildEnvFlags.awsRegion,
+			ProjectName: buildEnvFlags.awsProjectName,
+			ComputeType: buildEnvFlags.awsComputeType,
+			Timeout:     buildEnvFlags.timeout,
+			Concurrency: buildEnvFlags.concurrency,
+			DryRun:      buildEnvFlags.dryRun,
+			Output:      buildEnvFlags.outputFormat,
+		},
+	}
+}
+
+func addAzureBuildEnvOptions() inspect.Options {
+	return inspect.Options{
+		Filename:  inspectFlags.fileName,
+		OutFormat: inspectFlags.outFormat,
+		Modules:   inspectFlags.modules,
+		BuildEnvOptions: inspect.BuildEnvOptions{
+			Profile:           buildEnvFlags.profile,
+			Region:            buildEnvFlags.azureRegion,
+			ServiceConnection: buildEnvFlags.azureServiceConnection,
+			ProjectName:       buildEnvFlags.azureProjectName,
+			Timeout:           buildEnvFlags.timeout,
+			Concurrency:       buildEnvFlags.concurrency,
+			DryRun:            buildEnvFlags.dryRun,
+			Output:            buildEnvFlags.outputFormat,
 		},
 	}
 }
\ No newline at end of file
@@ -0,0 +1,197 @@
+/*
+Copyright 2021 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildEnv
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/inspect"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+)
+
+// AddGcbBuildEnv adds a new GoogleCloudBuild build environment definition to every module
+// targeted by opts, creating opts.Profile if it doesn't already exist. It fails if a
+// googleCloudBuild definition already exists for the target pipeline/profile. opts.Set and
+// opts.FromFile are merged with the explicit provider flags into a single patch (see
+// resolveGcbPatch) and applied identically across every targeted module, so the same
+// mutation engine can drive bulk edits over a multi-module project. With opts.DryRun set,
+// the pipeline/profile changes are printed as a structured diff instead of being written to
+// the target file(s).
+func AddGcbBuildEnv(ctx context.Context, out io.Writer, opts inspect.Options) error {
+	patch, err := resolveGcbPatch(opts)
+	if err != nil {
+		return err
+	}
+	if err := validateGcbWorkerPool(patch.WorkerPool, patch.Region, patch.MachineType, patch.DiskSizeGb); err != nil {
+		return err
+	}
+
+	docs, err := loadConfigDocs(opts.Filename, opts.Modules)
+	if err != nil {
+		return err
+	}
+
+	// Validate and mutate every targeted doc in memory first, so that a later doc failing
+	// its precondition can't leave earlier docs already written to disk.
+	var diffs []mutationDiff
+	for _, doc := range docs {
+		build, err := resolveBuild(doc, opts.Profile, true)
+		if err != nil {
+			return err
+		}
+		if build.GoogleCloudBuild != nil {
+			return fmt.Errorf("a googleCloudBuild build env definition already exists for %s; use 'skaffold inspect build-env modify' to update it", describeTarget(doc, opts.Profile))
+		}
+		before, err := yamlSnapshot(build.GoogleCloudBuild)
+		if err != nil {
+			return err
+		}
+		build.GoogleCloudBuild = &latest.GoogleCloudBuild{
+			ProjectID:   patch.ProjectID,
+			DiskSizeGb:  patch.DiskSizeGb,
+			MachineType: patch.MachineType,
+			Timeout:     patch.Timeout,
+			Concurrency: patch.Concurrency,
+			WorkerPool:  patch.WorkerPool,
+			Region:      patch.Region,
+		}
+		after, err := yamlSnapshot(build.GoogleCloudBuild)
+		if err != nil {
+			return err
+		}
+		diffs = append(diffs, mutationDiff{File: doc.path, Module: doc.cfg.Metadata.Name, Profile: opts.Profile, Before: before, After: after})
+	}
+	if opts.DryRun {
+		return renderDiffs(out, opts.Output, diffs)
+	}
+
+	var touched []string
+	for _, doc := range docs {
+		if err := writeConfigDoc(doc); err != nil {
+			return err
+		}
+		touched = append(touched, doc.path)
+		fmt.Fprintf(out, "added googleCloudBuild build env definition to %s\n", describeTarget(doc, opts.Profile))
+	}
+	if len(touched) > 1 {
+		fmt.Fprintf(out, "updated %d file(s): %s\n", len(touched), strings.Join(touched, ", "))
+	}
+	return nil
+}
+
+// AddAwsBuildEnv adds a new AWSCodeBuild build environment definition to every module
+// targeted by opts, creating opts.Profile if it doesn't already exist. It fails if an
+// awsCodeBuild definition already exists for the target pipeline/profile. With opts.DryRun
+// set, the pipeline/profile changes are printed as a structured diff instead of being
+// written to the target file(s).
+func AddAwsBuildEnv(ctx context.Context, out io.Writer, opts inspect.Options) error {
+	docs, err := loadConfigDocs(opts.Filename, opts.Modules)
+	if err != nil {
+		return err
+	}
+
+	var diffs []mutationDiff
+	for _, doc := range docs {
+		build, err := resolveBuild(doc, opts.Profile, true)
+		if err != nil {
+			return err
+		}
+		if build.AWSCodeBuild != nil {
+			return fmt.Errorf("an awsCodeBuild build env definition already exists for %s; use 'skaffold inspect build-env modify' to update it", describeTarget(doc, opts.Profile))
+		}
+		before, err := yamlSnapshot(build.AWSCodeBuild)
+		if err != nil {
+			return err
+		}
+		build.AWSCodeBuild = &latest.AWSCodeBuild{
+			Region:      opts.Region,
+			ProjectName: opts.ProjectName,
+			ComputeType: opts.ComputeType,
+			Timeout:     opts.Timeout,
+			Concurrency: resolvedConcurrency(opts.Concurrency),
+		}
+		after, err := yamlSnapshot(build.AWSCodeBuild)
+		if err != nil {
+			return err
+		}
+		diffs = append(diffs, mutationDiff{File: doc.path, Module: doc.cfg.Metadata.Name, Profile: opts.Profile, Before: before, After: after})
+	}
+	if opts.DryRun {
+		return renderDiffs(out, opts.Output, diffs)
+	}
+
+	for _, doc := range docs {
+		if err := writeConfigDoc(doc); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "added awsCodeBuild build env definition to %s\n", describeTarget(doc, opts.Profile))
+	}
+	return nil
+}
+
+// AddAzureBuildEnv adds a new AzurePipelines build environment definition to every module
+// targeted by opts, creating opts.Profile if it doesn't already exist. It fails if an
+// azurePipelines definition already exists for the target pipeline/profile. With
+// opts.DryRun set, the pipeline/profile changes are printed as a structured diff instead of
+// being written to the target file(s).
+func AddAzureBuildEnv(ctx context.Context, out io.Writer, opts inspect.Options) error {
+	docs, err := loadConfigDocs(opts.Filename, opts.Modules)
+	if err != nil {
+		return err
+	}
+
+	var diffs []mutationDiff
+	for _, doc := range docs {
+		build, err := resolveBuild(doc, opts.Profile, true)
+		if err != nil {
+			return err
+		}
+		if build.AzurePipelines != nil {
+			return fmt.Errorf("an azurePipelines build env definition already exists for %s; use 'skaffold inspect build-env modify' to update it", describeTarget(doc, opts.Profile))
+		}
+		before, err := yamlSnapshot(build.AzurePipelines)
+		if err != nil {
+			return err
+		}
+		build.AzurePipelines = &latest.AzurePipelines{
+			Region:            opts.Region,
+			ServiceConnection: opts.ServiceConnection,
+			ProjectName:       opts.ProjectName,
+			Timeout:           opts.Timeout,
+			Concurrency:       resolvedConcurrency(opts.Concurrency),
+		}
+		after, err := yamlSnapshot(build.AzurePipelines)
+		if err != nil {
+			return err
+		}
+		diffs = append(diffs, mutationDiff{File: doc.path, Module: doc.cfg.Metadata.Name, Profile: opts.Profile, Before: before, After: after})
+	}
+	if opts.DryRun {
+		return renderDiffs(out, opts.Output, diffs)
+	}
+
+	for _, doc := range docs {
+		if err := writeConfigDoc(doc); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "added azurePipelines build env definition to %s\n", describeTarget(doc, opts.Profile))
+	}
+	return nil
+}
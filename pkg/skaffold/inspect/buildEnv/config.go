@@ -0,0 +1,186 @@
+/*
+Copyright 2021 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package buildEnv implements the `skaffold inspect build-env` family of commands.
+package buildEnv
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+)
+
+// workerPoolPattern matches a fully qualified Cloud Build private worker pool resource name,
+// capturing the location it's scoped to.
+var workerPoolPattern = regexp.MustCompile(`^projects/[^/]+/locations/([^/]+)/workerPools/[^/]+$`)
+
+// validateGcbWorkerPool enforces that workerPool and region are mutually consistent, and that
+// machineType/diskSizeGb (pool-level settings) aren't set alongside a worker pool.
+func validateGcbWorkerPool(workerPool, region, machineType string, diskSizeGb int64) error {
+	if workerPool == "" {
+		return nil
+	}
+	m := workerPoolPattern.FindStringSubmatch(workerPool)
+	if m == nil {
+		return fmt.Errorf(`workerPool %q is not a valid resource name; expected the form "projects/*/locations/*/workerPools/*"`, workerPool)
+	}
+	if region != "" && region != m[1] {
+		return fmt.Errorf("region %q does not match the location %q encoded in workerPool %q", region, m[1], workerPool)
+	}
+	if machineType != "" {
+		return fmt.Errorf("machineType cannot be set alongside workerPool %q; machine type is configured on the worker pool itself", workerPool)
+	}
+	if diskSizeGb != 0 {
+		return fmt.Errorf("diskSizeGb cannot be set alongside workerPool %q; disk size is configured on the worker pool itself", workerPool)
+	}
+	return nil
+}
+
+// configDoc is an in-memory skaffold.yaml document together with the file path it was read from.
+type configDoc struct {
+	path string
+	cfg  *latest.SkaffoldConfig
+}
+
+// loadConfigDocs reads the target skaffold.yaml and every config it `requires`, recursively,
+// filtered down to the named modules (matched against each config's `metadata.name`). An empty
+// `modules` filter returns every config found.
+func loadConfigDocs(filename string, modules []string) ([]*configDoc, error) {
+	seen := map[string]bool{}
+	var docs []*configDoc
+
+	var visit func(path string) error
+	visit = func(path string) error {
+		if seen[path] {
+			return nil
+		}
+		seen[path] = true
+
+		buf, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading %q: %w", path, err)
+		}
+		cfg := &latest.SkaffoldConfig{}
+		if err := yaml.Unmarshal(buf, cfg); err != nil {
+			return fmt.Errorf("parsing %q: %w", path, err)
+		}
+		docs = append(docs, &configDoc{path: path, cfg: cfg})
+
+		for _, dep := range cfg.Dependencies {
+			if err := visit(dep.Path); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := visit(filename); err != nil {
+		return nil, err
+	}
+
+	if len(modules) == 0 {
+		return docs, nil
+	}
+	want := make(map[string]bool, len(modules))
+	for _, m := range modules {
+		want[m] = true
+	}
+	var filtered []*configDoc
+	for _, doc := range docs {
+		if want[doc.cfg.Metadata.Name] {
+			filtered = append(filtered, doc)
+		}
+	}
+	if len(filtered) == 0 {
+		return nil, fmt.Errorf("no modules matched filter %v in %q", modules, filename)
+	}
+	return filtered, nil
+}
+
+// writeConfigDoc marshals the config back to its source file.
+func writeConfigDoc(doc *configDoc) error {
+	buf, err := yaml.Marshal(doc.cfg)
+	if err != nil {
+		return fmt.Errorf("marshaling %q: %w", doc.path, err)
+	}
+	if err := ioutil.WriteFile(doc.path, buf, 0644); err != nil {
+		return fmt.Errorf("writing %q: %w", doc.path, err)
+	}
+	return nil
+}
+
+// resolveBuild returns the `BuildConfig` for the given profile name in doc ("" selects the
+// default pipeline). When create is false it returns an error if the profile does not exist;
+// when create is true it creates an empty profile entry on demand.
+func resolveBuild(doc *configDoc, profile string, create bool) (*latest.BuildConfig, error) {
+	if profile == "" {
+		return &doc.cfg.Build, nil
+	}
+	for i := range doc.cfg.Profiles {
+		if doc.cfg.Profiles[i].Name == profile {
+			return &doc.cfg.Profiles[i].Build, nil
+		}
+	}
+	if !create {
+		return nil, fmt.Errorf("profile %q not found in %q", profile, doc.path)
+	}
+	doc.cfg.Profiles = append(doc.cfg.Profiles, latest.Profile{Name: profile})
+	return &doc.cfg.Profiles[len(doc.cfg.Profiles)-1].Build, nil
+}
+
+// describeTarget renders a human-readable label for the pipeline/profile a mutation applies to.
+func describeTarget(doc *configDoc, profile string) string {
+	if profile == "" {
+		return fmt.Sprintf("the default pipeline of %q", doc.path)
+	}
+	return fmt.Sprintf("profile %q of %q", profile, doc.path)
+}
+
+// resolveActiveBuild returns the build env active for doc once the named profiles are
+// applied, in activation order: a profile whose build config sets a builder replaces the
+// build config resolved so far, while a profile that leaves its own build config empty keeps
+// it (the default pipeline's, or an earlier profile's), so a profile that doesn't redefine a
+// builder doesn't hide the inherited one.
+func resolveActiveBuild(doc *configDoc, profiles []string) *latest.BuildConfig {
+	build := doc.cfg.Build
+	for _, profile := range profiles {
+		for i := range doc.cfg.Profiles {
+			if doc.cfg.Profiles[i].Name != profile {
+				continue
+			}
+			if pb := doc.cfg.Profiles[i].Build; !isEmptyBuildConfig(pb) {
+				build = pb
+			}
+		}
+	}
+	return &build
+}
+
+// isEmptyBuildConfig reports whether b has no builder configured at all.
+func isEmptyBuildConfig(b latest.BuildConfig) bool {
+	return b.GoogleCloudBuild == nil && b.AWSCodeBuild == nil && b.AzurePipelines == nil
+}
+
+// resolvedConcurrency maps the CLI's "-1 means unset" sentinel to the schema's zero value.
+func resolvedConcurrency(c int) int {
+	if c < 0 {
+		return 0
+	}
+	return c
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2021 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildEnv
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// mutationDiff is a machine-readable record of a single add/modify/remove mutation, printed
+// instead of being written to disk when '--dry-run' is set.
+type mutationDiff struct {
+	File    string `json:"file" yaml:"file"`
+	Module  string `json:"module" yaml:"module"`
+	Profile string `json:"profile,omitempty" yaml:"profile,omitempty"`
+	Before  string `json:"before" yaml:"before"`
+	After   string `json:"after" yaml:"after"`
+}
+
+// renderDiffs prints diffs in the requested output format ("json" or "yaml").
+func renderDiffs(out io.Writer, output string, diffs []mutationDiff) error {
+	if output == "yaml" {
+		buf, err := yaml.Marshal(diffs)
+		if err != nil {
+			return fmt.Errorf("marshaling diff: %w", err)
+		}
+		_, err = out.Write(buf)
+		return err
+	}
+	return json.NewEncoder(out).Encode(diffs)
+}
+
+// yamlSnapshot marshals v to a YAML fragment, or returns "" if v is a nil pointer.
+func yamlSnapshot(v interface{}) (string, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return "", nil
+	}
+	buf, err := yaml.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("marshaling snapshot: %w", err)
+	}
+	return string(buf), nil
+}
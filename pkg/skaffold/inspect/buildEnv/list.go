@@ -0,0 +1,73 @@
+/*
+Copyright 2021 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildEnv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/inspect"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+)
+
+// buildEnvEntry describes the build environment active for a single module.
+type buildEnvEntry struct {
+	Module string `json:"module"`
+	Type   string `json:"type"`
+}
+
+// PrintBuildEnvsList prints the list of active build environments, one per targeted module,
+// honoring opts.Profiles to select which profile's pipeline (if any) is active.
+func PrintBuildEnvsList(ctx context.Context, out io.Writer, opts inspect.Options) error {
+	docs, err := loadConfigDocs(opts.Filename, opts.Modules)
+	if err != nil {
+		return err
+	}
+
+	var entries []buildEnvEntry
+	for _, doc := range docs {
+		build := resolveActiveBuild(doc, opts.Profiles)
+		entries = append(entries, buildEnvEntry{
+			Module: doc.cfg.Metadata.Name,
+			Type:   buildEnvType(build),
+		})
+	}
+
+	if opts.OutFormat == "json" {
+		return json.NewEncoder(out).Encode(entries)
+	}
+	for _, e := range entries {
+		fmt.Fprintf(out, "%s: %s\n", e.Module, e.Type)
+	}
+	return nil
+}
+
+// buildEnvType returns the name of the builder configured on b, or "local" if none is set.
+func buildEnvType(b *latest.BuildConfig) string {
+	switch {
+	case b.GoogleCloudBuild != nil:
+		return "googleCloudBuild"
+	case b.AWSCodeBuild != nil:
+		return "awsCodeBuild"
+	case b.AzurePipelines != nil:
+		return "azurePipelines"
+	default:
+		return "local"
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildEnv
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/inspect"
+)
+
+// ModifyGcbBuildEnv patches an existing GoogleCloudBuild build environment definition for
+// every module targeted by opts. It only overrides the fields the caller explicitly set
+// (a zero-valued string/int64 flag, or concurrency's "-1 means unset" sentinel, leave the
+// existing value untouched) and returns an error if the target definition does not exist.
+// With opts.DryRun set, the pipeline/profile changes are printed as a structured diff
+// instead of being written to the target file(s).
+func ModifyGcbBuildEnv(ctx context.Context, out io.Writer, opts inspect.Options) error {
+	docs, err := loadConfigDocs(opts.Filename, opts.Modules)
+	if err != nil {
+		return err
+	}
+
+	var diffs []mutationDiff
+	for _, doc := range docs {
+		build, err := resolveBuild(doc, opts.Profile, false)
+		if err != nil {
+			return err
+		}
+		if build.GoogleCloudBuild == nil {
+			return fmt.Errorf("no googleCloudBuild build env definition exists for %s; use 'skaffold inspect build-env add' to create one", describeTarget(doc, opts.Profile))
+		}
+
+		gcb := build.GoogleCloudBuild
+		before, err := yamlSnapshot(gcb)
+		if err != nil {
+			return err
+		}
+
+		if opts.ProjectID != "" {
+			gcb.ProjectID = opts.ProjectID
+		}
+		if opts.DiskSizeGb != 0 {
+			gcb.DiskSizeGb = opts.DiskSizeGb
+		}
+		if opts.MachineType != "" {
+			gcb.MachineType = opts.MachineType
+		}
+		if opts.Timeout != "" {
+			gcb.Timeout = opts.Timeout
+		}
+		if opts.Concurrency >= 0 {
+			gcb.Concurrency = opts.Concurrency
+		}
+		if opts.WorkerPool != "" {
+			gcb.WorkerPool = opts.WorkerPool
+		}
+		if opts.Region != "" {
+			gcb.Region = opts.Region
+		}
+		if err := validateGcbWorkerPool(gcb.WorkerPool, gcb.Region, gcb.MachineType, gcb.DiskSizeGb); err != nil {
+			return err
+		}
+
+		after, err := yamlSnapshot(gcb)
+		if err != nil {
+			return err
+		}
+		diffs = append(diffs, mutationDiff{File: doc.path, Module: doc.cfg.Metadata.Name, Profile: opts.Profile, Before: before, After: after})
+	}
+	if opts.DryRun {
+		return renderDiffs(out, opts.Output, diffs)
+	}
+
+	// Every doc validated and mutated in memory above; only now commit them to disk, so a
+	// later doc failing its precondition can't leave earlier docs partially written.
+	for _, doc := range docs {
+		if err := writeConfigDoc(doc); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "updated googleCloudBuild build env definition for %s\n", describeTarget(doc, opts.Profile))
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildEnv
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/inspect"
+)
+
+// gcbPatch is the resolved set of GoogleCloudBuild field overrides to apply to every target
+// pipeline/profile, shared by the CLI and any future programmatic callers of AddGcbBuildEnv.
+type gcbPatch struct {
+	ProjectID   string `json:"projectId,omitempty" yaml:"projectId,omitempty"`
+	DiskSizeGb  int64  `json:"diskSizeGb,omitempty" yaml:"diskSizeGb,omitempty"`
+	MachineType string `json:"machineType,omitempty" yaml:"machineType,omitempty"`
+	Timeout     string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Concurrency int    `json:"concurrency,omitempty" yaml:"concurrency,omitempty"`
+	WorkerPool  string `json:"workerPool,omitempty" yaml:"workerPool,omitempty"`
+	Region      string `json:"region,omitempty" yaml:"region,omitempty"`
+}
+
+// resolveGcbPatch merges opts.FromFile, opts.Set, and the explicit provider flags into a
+// single patch. Later sources override earlier ones: from-file, then set, then flags.
+func resolveGcbPatch(opts inspect.Options) (gcbPatch, error) {
+	var patch gcbPatch
+
+	if opts.FromFile != "" {
+		buf, err := ioutil.ReadFile(opts.FromFile)
+		if err != nil {
+			return patch, fmt.Errorf("reading --from-file %q: %w", opts.FromFile, err)
+		}
+		if err := yaml.Unmarshal(buf, &patch); err != nil {
+			return patch, fmt.Errorf("parsing --from-file %q: %w", opts.FromFile, err)
+		}
+	}
+
+	for _, kv := range opts.Set {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 {
+			return patch, fmt.Errorf("invalid --set %q: expected key=value", kv)
+		}
+		if err := applyGcbPatchField(&patch, parts[0], parts[1]); err != nil {
+			return patch, err
+		}
+	}
+
+	if opts.ProjectID != "" {
+		patch.ProjectID = opts.ProjectID
+	}
+	if opts.DiskSizeGb != 0 {
+		patch.DiskSizeGb = opts.DiskSizeGb
+	}
+	if opts.MachineType != "" {
+		patch.MachineType = opts.MachineType
+	}
+	if opts.Timeout != "" {
+		patch.Timeout = opts.Timeout
+	}
+	if opts.Concurrency >= 0 {
+		patch.Concurrency = resolvedConcurrency(opts.Concurrency)
+	}
+	if opts.WorkerPool != "" {
+		patch.WorkerPool = opts.WorkerPool
+	}
+	if opts.Region != "" {
+		patch.Region = opts.Region
+	}
+
+	return patch, nil
+}
+
+// applyGcbPatchField sets the field named by a single --set key=value pair on patch.
+func applyGcbPatchField(patch *gcbPatch, key, value string) error {
+	switch key {
+	case "projectId":
+		patch.ProjectID = value
+	case "diskSizeGb":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid --set diskSizeGb=%q: %w", value, err)
+		}
+		patch.DiskSizeGb = n
+	case "machineType":
+		patch.MachineType = value
+	case "timeout":
+		patch.Timeout = value
+	case "concurrency":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid --set concurrency=%q: %w", value, err)
+		}
+		patch.Concurrency = n
+	case "workerPool":
+		patch.WorkerPool = value
+	case "region":
+		patch.Region = value
+	default:
+		return fmt.Errorf("unsupported --set key %q", key)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2021 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildEnv
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/inspect"
+)
+
+// RemoveGcbBuildEnv removes an existing GoogleCloudBuild build environment definition from
+// every module targeted by opts, returning an error if the target definition does not
+// exist. With opts.DryRun set, the pipeline/profile changes are printed as a structured
+// diff instead of being written to the target file(s).
+func RemoveGcbBuildEnv(ctx context.Context, out io.Writer, opts inspect.Options) error {
+	docs, err := loadConfigDocs(opts.Filename, opts.Modules)
+	if err != nil {
+		return err
+	}
+
+	var diffs []mutationDiff
+	for _, doc := range docs {
+		build, err := resolveBuild(doc, opts.Profile, false)
+		if err != nil {
+			return err
+		}
+		if build.GoogleCloudBuild == nil {
+			return fmt.Errorf("no googleCloudBuild build env definition exists for %s", describeTarget(doc, opts.Profile))
+		}
+
+		before, err := yamlSnapshot(build.GoogleCloudBuild)
+		if err != nil {
+			return err
+		}
+		build.GoogleCloudBuild = nil
+		after, err := yamlSnapshot(build.GoogleCloudBuild)
+		if err != nil {
+			return err
+		}
+		diffs = append(diffs, mutationDiff{File: doc.path, Module: doc.cfg.Metadata.Name, Profile: opts.Profile, Before: before, After: after})
+	}
+	if opts.DryRun {
+		return renderDiffs(out, opts.Output, diffs)
+	}
+
+	// Every doc validated and mutated in memory above; only now commit them to disk, so a
+	// later doc failing its precondition can't leave earlier docs partially written.
+	for _, doc := range docs {
+		if err := writeConfigDoc(doc); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "removed googleCloudBuild build env definition from %s\n", describeTarget(doc, opts.Profile))
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildEnv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/inspect"
+	"github.com/GoogleContainerTools/skaffold/pkg/skaffold/schema/latest"
+)
+
+// allowedGcbMachineTypes are the machine types Cloud Build currently accepts for `machineType`.
+// An empty value is allowed: it defers to Cloud Build's own default machine type.
+var allowedGcbMachineTypes = map[string]bool{
+	"":              true,
+	"E2_HIGHCPU_8":  true,
+	"E2_HIGHCPU_32": true,
+	"N1_HIGHCPU_8":  true,
+	"N1_HIGHCPU_32": true,
+}
+
+// validationResult reports whether a single module's active build environment passed validation.
+type validationResult struct {
+	Module string   `json:"module"`
+	Type   string   `json:"type"`
+	Valid  bool     `json:"valid"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+// ValidateBuildEnvs statically checks every active build environment definition targeted by
+// opts against provider-specific constraints (only GoogleCloudBuild definitions have checks;
+// AWSCodeBuild and AzurePipelines definitions are always reported valid), printing the results in opts.OutFormat ("json"
+// or human-readable text) and returning an error if any definition fails validation, so
+// callers can gate on a non-zero exit code.
+func ValidateBuildEnvs(ctx context.Context, out io.Writer, opts inspect.Options) error {
+	docs, err := loadConfigDocs(opts.Filename, opts.Modules)
+	if err != nil {
+		return err
+	}
+
+	var results []validationResult
+	failures := 0
+	for _, doc := range docs {
+		build := resolveActiveBuild(doc, opts.Profiles)
+
+		r := validationResult{Module: doc.cfg.Metadata.Name, Type: buildEnvType(build), Valid: true}
+		for _, err := range validateGcb(build.GoogleCloudBuild) {
+			r.Valid = false
+			r.Errors = append(r.Errors, err.Error())
+		}
+		if !r.Valid {
+			failures++
+		}
+		results = append(results, r)
+	}
+
+	if opts.OutFormat == "json" {
+		if err := json.NewEncoder(out).Encode(results); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			status := "OK"
+			if !r.Valid {
+				status = "INVALID"
+			}
+			fmt.Fprintf(out, "%s (%s): %s\n", r.Module, r.Type, status)
+			for _, e := range r.Errors {
+				fmt.Fprintf(out, "  - %s\n", e)
+			}
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%d build env definition(s) failed validation", failures)
+	}
+	return nil
+}
+
+// validateGcb checks a GoogleCloudBuild definition against Cloud Build's provider constraints.
+// A nil definition (no googleCloudBuild builder configured) is always valid.
+func validateGcb(gcb *latest.GoogleCloudBuild) []error {
+	if gcb == nil {
+		return nil
+	}
+	var errs []error
+
+	if !allowedGcbMachineTypes[gcb.MachineType] {
+		errs = append(errs, fmt.Errorf("machineType %q is not a recognized Cloud Build machine type", gcb.MachineType))
+	}
+	if gcb.DiskSizeGb != 0 && (gcb.DiskSizeGb < 10 || gcb.DiskSizeGb > 4000) {
+		errs = append(errs, fmt.Errorf("diskSizeGb %d is outside the allowed range [10, 4000]", gcb.DiskSizeGb))
+	}
+	if gcb.Timeout != "" {
+		if _, err := time.ParseDuration(gcb.Timeout); err != nil {
+			if _, serr := strconv.Atoi(gcb.Timeout); serr != nil {
+				errs = append(errs, fmt.Errorf("timeout %q is neither a valid Go duration nor a plain seconds value", gcb.Timeout))
+			}
+		}
+	}
+	if gcb.Concurrency < 0 {
+		errs = append(errs, fmt.Errorf("concurrency %d must be non-negative", gcb.Concurrency))
+	}
+	if gcb.ProjectID == "" {
+		errs = append(errs, fmt.Errorf("projectId must be set"))
+	}
+	if err := validateGcbWorkerPool(gcb.WorkerPool, gcb.Region, gcb.MachineType, gcb.DiskSizeGb); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
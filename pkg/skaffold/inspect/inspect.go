@@ -0,0 +1,88 @@
+/*
+Copyright 2021 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package inspect defines the option types shared by the `skaffold inspect` family of commands.
+package inspect
+
+// Options holds the flag values common to every `skaffold inspect` subcommand.
+type Options struct {
+	// Filename is the path to the target skaffold.yaml.
+	Filename string
+
+	// OutFormat is the format ("json" or "text") results are printed in.
+	OutFormat string
+
+	// Modules filters the target action to the named modules. An empty slice targets every module.
+	Modules []string
+
+	BuildEnvOptions
+}
+
+// BuildEnvOptions holds the flag values specific to `skaffold inspect build-env` subcommands.
+type BuildEnvOptions struct {
+	// Profiles is the set of profiles to activate when listing build environments.
+	Profiles []string
+
+	// Profile is the profile to add, modify or remove a build environment definition in.
+	// An empty string targets the default pipeline.
+	Profile string
+
+	// ProjectID is the ID of the Cloud Platform Project (GoogleCloudBuild).
+	ProjectID string
+
+	// DiskSizeGb is the disk size of the VM that runs the build (GoogleCloudBuild).
+	DiskSizeGb int64
+
+	// MachineType is the type of VM that runs the build (GoogleCloudBuild).
+	MachineType string
+
+	// Timeout is the build timeout, in seconds.
+	Timeout string
+
+	// Concurrency is how many artifacts can be built concurrently. 0 means "no-limit", -1 means "unset".
+	Concurrency int
+
+	// Region is the cloud region to run the build in (GoogleCloudBuild, AWSCodeBuild, AzurePipelines).
+	Region string
+
+	// WorkerPool is the fully qualified resource name of a Cloud Build private worker pool
+	// (GoogleCloudBuild).
+	WorkerPool string
+
+	// ProjectName is the name of the remote build project (AWSCodeBuild, AzurePipelines).
+	ProjectName string
+
+	// ComputeType is the compute type to use for the build (AWSCodeBuild).
+	ComputeType string
+
+	// ServiceConnection is the name of the service connection to use for the build (AzurePipelines).
+	ServiceConnection string
+
+	// DryRun, when set, makes add/modify/remove print a structured diff of the change instead
+	// of writing it to the target file(s).
+	DryRun bool
+
+	// Output is the format ("json" or "yaml") the '--dry-run' diff is printed in.
+	Output string
+
+	// Set is a list of "key=value" overrides; an explicit provider flag for the same key
+	// takes precedence over it.
+	Set []string
+
+	// FromFile is the path to a YAML overlay file of key/value overrides, applied the same
+	// way as Set.
+	FromFile string
+}
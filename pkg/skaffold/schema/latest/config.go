@@ -0,0 +1,155 @@
+/*
+Copyright 2021 The Skaffold Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package latest
+
+// SkaffoldConfig is the top level config object that is parsed from a skaffold.yaml.
+type SkaffoldConfig struct {
+	// APIVersion is the version of the configuration.
+	APIVersion string `yaml:"apiVersion" yamltags:"required"`
+
+	// Kind is always `Config`.
+	Kind string `yaml:"kind" yamltags:"required"`
+
+	// Metadata holds additional information about the config.
+	Metadata Metadata `yaml:"metadata,omitempty"`
+
+	Pipeline `yaml:",inline"`
+
+	// Profiles can override be used to override any `build`, `test` or `deploy` configuration.
+	Profiles []Profile `yaml:"profiles,omitempty"`
+
+	// Dependencies describes a list of other required configs for the current config.
+	Dependencies []ConfigDependency `yaml:"requires,omitempty"`
+}
+
+// Metadata holds an optional name of the project.
+type Metadata struct {
+	// Name is an identifier for the project.
+	Name string `yaml:"name,omitempty"`
+}
+
+// ConfigDependency describes a dependency on another skaffold.yaml.
+type ConfigDependency struct {
+	// Path describes the path to the file containing the required configs.
+	Path string `yaml:"path,omitempty"`
+
+	// Names includes specific named configs within the file path.
+	Names []string `yaml:"configs,omitempty"`
+}
+
+// Pipeline describes a Skaffold pipeline.
+type Pipeline struct {
+	// Build describes how images are built.
+	Build BuildConfig `yaml:"build,omitempty"`
+}
+
+// Profile is used to override any `build`, `test` or `deploy` configuration.
+type Profile struct {
+	// Name is a unique profile name.
+	Name string `yaml:"name,omitempty" yamltags:"required"`
+
+	Pipeline `yaml:",inline"`
+}
+
+// BuildConfig contains all the configuration for the build steps.
+type BuildConfig struct {
+	// BuildType contains the specific implementation and parameters needed
+	// to build artifacts. Only one field should be populated.
+	BuildType `yaml:",inline"`
+}
+
+// BuildType contains the specific implementation and parameters needed
+// to build artifacts.
+type BuildType struct {
+	// GoogleCloudBuild describes how to do a remote build on
+	// [Google Cloud Build](https://cloud.google.com/cloud-build/).
+	GoogleCloudBuild *GoogleCloudBuild `yaml:"googleCloudBuild,omitempty" yamltags:"oneOf=build"`
+
+	// AWSCodeBuild describes how to do a remote build on
+	// [AWS CodeBuild](https://aws.amazon.com/codebuild/).
+	AWSCodeBuild *AWSCodeBuild `yaml:"awsCodeBuild,omitempty" yamltags:"oneOf=build"`
+
+	// AzurePipelines describes how to do a remote build on
+	// [Azure Pipelines](https://azure.microsoft.com/en-us/products/devops/pipelines/).
+	AzurePipelines *AzurePipelines `yaml:"azurePipelines,omitempty" yamltags:"oneOf=build"`
+}
+
+// GoogleCloudBuild contains the fields needed to do a remote build on
+// [Google Cloud Build](https://cloud.google.com/cloud-build/docs/).
+type GoogleCloudBuild struct {
+	// ProjectID is the ID of your Cloud Platform Project.
+	ProjectID string `yaml:"projectId,omitempty"`
+
+	// DiskSizeGb is the disk size of the VM that runs the build.
+	DiskSizeGb int64 `yaml:"diskSizeGb,omitempty"`
+
+	// MachineType is the type of the VM that runs the build.
+	MachineType string `yaml:"machineType,omitempty"`
+
+	// Timeout is the amount of time (in seconds) that this build should be allowed to run.
+	Timeout string `yaml:"timeout,omitempty"`
+
+	// Concurrency is how many artifacts can be built concurrently. 0 means "no-limit".
+	Concurrency int `yaml:"concurrency,omitempty"`
+
+	// WorkerPool is the fully qualified resource name of the private worker pool to run the
+	// build in, e.g. `projects/[project]/locations/[location]/workerPools/[workerPool]`.
+	// Mutually exclusive with `MachineType` and `DiskSizeGb`, which are pool-level settings.
+	WorkerPool string `yaml:"workerPool,omitempty"`
+
+	// Region is the Cloud Build regional endpoint to use, e.g. `us-central1`. Must match the
+	// location encoded in `WorkerPool` when set.
+	Region string `yaml:"region,omitempty"`
+}
+
+// AWSCodeBuild contains the fields needed to do a remote build on
+// [AWS CodeBuild](https://docs.aws.amazon.com/codebuild/).
+type AWSCodeBuild struct {
+	// Region is the AWS region to run the build in.
+	Region string `yaml:"region,omitempty"`
+
+	// ProjectName is the name of the AWS CodeBuild project.
+	ProjectName string `yaml:"projectName,omitempty"`
+
+	// ComputeType is the compute type to use for the build, e.g. `BUILD_GENERAL1_SMALL`.
+	ComputeType string `yaml:"computeType,omitempty"`
+
+	// Timeout is the amount of time (in seconds) that this build should be allowed to run.
+	Timeout string `yaml:"timeout,omitempty"`
+
+	// Concurrency is how many artifacts can be built concurrently. 0 means "no-limit".
+	Concurrency int `yaml:"concurrency,omitempty"`
+}
+
+// AzurePipelines contains the fields needed to do a remote build on
+// [Azure Pipelines](https://learn.microsoft.com/en-us/azure/devops/pipelines/).
+type AzurePipelines struct {
+	// Region is the Azure region to run the build in.
+	Region string `yaml:"region,omitempty"`
+
+	// ServiceConnection is the name of the Azure service connection to use for the build.
+	ServiceConnection string `yaml:"serviceConnection,omitempty"`
+
+	// ProjectName is the name of the Azure DevOps project.
+	ProjectName string `yaml:"projectName,omitempty"`
+
+	// Timeout is the amount of time (in seconds) that this build should be allowed to run.
+	Timeout string `yaml:"timeout,omitempty"`
+
+	// Concurrency is how many artifacts can be built concurrently. 0 means "no-limit".
+	Concurrency int `yaml:"concurrency,omitempty"`
+}